@@ -4,11 +4,15 @@
 package cli
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
-	"github.com/maloquacious/wxx/xmlio"
 	"github.com/playbymail/otto/config"
+	"github.com/playbymail/otto/info"
 	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+	"strings"
 )
 
 var Command = &cobra.Command{
@@ -16,23 +20,70 @@ var Command = &cobra.Command{
 	Short: "Copy map data to a new file",
 	Long:  `Copy map data to a new file, keeping only information used by Otto.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// read the input
 		from, err := cmd.Flags().GetString("from")
 		if err != nil {
 			return fmt.Errorf("could not read --from: %w", err)
 		}
-		w, err := xmlio.ReadFile(from)
+		from = ottoConfig.MapPath(from)
+		to, err := cmd.Flags().GetString("to")
 		if err != nil {
-			return errors.Join(fmt.Errorf("copy: xmlio.Read"), err)
+			return fmt.Errorf("could not read --to: %w", err)
 		}
-		fmt.Printf("\t%8d tiles high\n", w.Tiles.TilesHigh)
-		fmt.Printf("\t%8d tiles wide\n", w.Tiles.TilesWide)
-		fmt.Printf("\t%8d terrain tiles defined\n", len(w.TerrainMap.List))
-		return fmt.Errorf("not implemented")
+		to = ottoConfig.MapPath(to)
+		if !strings.HasSuffix(to, ".wxx") {
+			return fmt.Errorf("copy: --to: not a '.wxx' file")
+		}
+		if sameFile(from, to) {
+			return fmt.Errorf("copy: --to: must not be the same as --from")
+		}
+		encoding, err := cmd.Flags().GetString("encoding")
+		if err != nil {
+			return fmt.Errorf("could not read --encoding: %w", err)
+		}
+		// report errors against the setting the user actually gave us
+		source := "--encoding"
+		if !cmd.Flags().Changed("encoding") && ottoConfig != nil && ottoConfig.OutputEncoding != "" {
+			encoding, source = ottoConfig.OutputEncoding, "config: output_encoding"
+		}
+		switch encoding {
+		case encUTF16BEGzip, encUTF16BEPlain, encUTF8Plain:
+		default:
+			return fmt.Errorf("copy: %s: must be one of %s, %s, or %s", source, encUTF16BEGzip, encUTF16BEPlain, encUTF8Plain)
+		}
+
+		// read the input, converting it to UTF-8
+		input, err := info.ReadFile(from)
+		if err != nil {
+			return errors.Join(fmt.Errorf("copy: read"), err)
+		}
+
+		// parse the input to make sure that we only write valid maps
+		w, err := info.ReadUTF8XML(bytes.NewReader(input))
+		if err != nil {
+			return errors.Join(fmt.Errorf("copy: read"), err)
+		}
+		log := ottoConfig.Logger()
+		log.Printf("\t%8d tiles high\n", w.Tiles.TilesHigh)
+		log.Printf("\t%8d tiles wide\n", w.Tiles.TilesWide)
+		log.Printf("\t%8d terrain tiles defined\n", len(w.TerrainMap.List))
+
+		// write the output. the default is gzip'd UTF-16/BE, which is what Worldographer expects.
+		if err := writeMap(to, input, encoding); err != nil {
+			return errors.Join(fmt.Errorf("copy: write"), err)
+		}
+		if sb, err := os.Stat(to); err == nil {
+			log.Verbosef("\t%8d bytes written as %s\n", sb.Size(), encoding)
+		}
+
+		return nil
 	},
 }
 
+// ottoConfig is the configuration passed to RegisterArgs.
+var ottoConfig *config.Config_t
+
 func RegisterArgs(cfg *config.Config_t) error {
+	ottoConfig = cfg
 	Command.Flags().String("from", "", "name of map file to copy from")
 	if err := Command.MarkFlagRequired("from"); err != nil {
 		return errors.Join(fmt.Errorf("copy"), err)
@@ -41,5 +92,19 @@ func RegisterArgs(cfg *config.Config_t) error {
 	if err := Command.MarkFlagRequired("to"); err != nil {
 		return errors.Join(fmt.Errorf("copy"), err)
 	}
+	Command.Flags().String("encoding", encUTF16BEGzip, fmt.Sprintf("output encoding: %s, %s, or %s", encUTF16BEGzip, encUTF16BEPlain, encUTF8Plain))
 	return nil
 }
+
+// sameFile returns true if both paths resolve to the same file name.
+func sameFile(a, b string) bool {
+	aa, err := filepath.Abs(a)
+	if err != nil {
+		return false
+	}
+	bb, err := filepath.Abs(b)
+	if err != nil {
+		return false
+	}
+	return aa == bb
+}
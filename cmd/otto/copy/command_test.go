@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/playbymail/otto/config"
+	"github.com/playbymail/otto/info"
+	"github.com/playbymail/otto/internal/fixture"
+	"github.com/spf13/pflag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runCopy runs the copy command with the given flags.
+func runCopy(t *testing.T, args ...string) error {
+	t.Helper()
+	if ottoConfig == nil {
+		if err := RegisterArgs(&config.Config_t{}); err != nil {
+			t.Fatalf("RegisterArgs: %v", err)
+		}
+	}
+	if ottoConfig.Log.W == nil {
+		ottoConfig.Log.W = io.Discard
+	}
+	// cobra keeps flag values between runs, so reset them to the defaults
+	Command.Flags().VisitAll(func(f *pflag.Flag) {
+		_ = f.Value.Set(f.DefValue)
+		f.Changed = false
+	})
+	Command.SilenceUsage, Command.SilenceErrors = true, true
+	Command.SetArgs(args)
+	return Command.Execute()
+}
+
+func TestCopy(t *testing.T) {
+	from := fixture.Path("h2017.wxx")
+	to := filepath.Join(t.TempDir(), "copy.wxx")
+	if err := runCopy(t, "--from", from, "--to", to, "--encoding", encUTF16BEGzip); err != nil {
+		t.Fatalf("copy: want nil, got %v", err)
+	}
+	if sb, err := os.Stat(to); err != nil {
+		t.Fatalf("copy: --to: %v", err)
+	} else if sb.Size() == 0 {
+		t.Errorf("copy: --to: want data, got empty file")
+	}
+}
+
+func TestCopyVerbose(t *testing.T) {
+	// the verbose log reports the size of the file on disk, not the utf-8 input
+	buf := &bytes.Buffer{}
+	setConfig(t, &config.Config_t{Log: config.Logger_t{Level: config.LogVerbose, W: buf}})
+	from := fixture.Path("h2017.wxx")
+	to := filepath.Join(t.TempDir(), "copy.wxx")
+	if err := runCopy(t, "--from", from, "--to", to, "--encoding", encUTF16BEPlain); err != nil {
+		t.Fatalf("copy: want nil, got %v", err)
+	}
+	sb, err := os.Stat(to)
+	if err != nil {
+		t.Fatalf("copy: --to: %v", err)
+	}
+	want := fmt.Sprintf("%8d bytes written as %s\n", sb.Size(), encUTF16BEPlain)
+	if got := buf.String(); !strings.Contains(got, want) {
+		t.Errorf("verbose: want %q in output, got\n%s", want, got)
+	}
+}
+
+func TestCopyRejects(t *testing.T) {
+	from := fixture.Path("h2017.wxx")
+	notWxx := filepath.Join(t.TempDir(), "copy.xml")
+	for _, tc := range []struct {
+		id   string
+		to   string
+		want string
+	}{
+		{id: "same file", to: from, want: "must not be the same as --from"},
+		{id: "not wxx", to: notWxx, want: "not a '.wxx' file"},
+	} {
+		err := runCopy(t, "--from", from, "--to", tc.to, "--encoding", encUTF16BEGzip)
+		if err == nil {
+			t.Errorf("%s: want error, got nil", tc.id)
+		} else if !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: want %q, got %q", tc.id, tc.want, err.Error())
+		}
+	}
+	if _, err := os.Stat(notWxx); err == nil {
+		t.Errorf("not wxx: want no file, got file")
+	}
+}
+
+func TestCopyConfig(t *testing.T) {
+	testdata := filepath.Dir(fixture.Path("h2017.wxx"))
+	for _, tc := range []struct {
+		id       string
+		encoding string
+		want     string // error
+	}{
+		{id: "utf8-plain", encoding: encUTF8Plain},
+		{id: "bad encoding", encoding: "utf-32", want: "copy: config: output_encoding: must be one of"},
+	} {
+		// the configuration sets the maps folder and the default output encoding
+		path := filepath.Join(t.TempDir(), "otto.yaml")
+		yaml := fmt.Sprintf("maps_dir: %q\noutput_encoding: %q\n", testdata, tc.encoding)
+		if err := os.WriteFile(path, []byte(yaml), 0644); err != nil {
+			t.Fatal(err)
+		}
+		cfg := &config.Config_t{}
+		if err := cfg.Load(path, false); err != nil {
+			t.Fatalf("%s: load: want nil, got %v", tc.id, err)
+		}
+		setConfig(t, cfg)
+
+		to := filepath.Join(t.TempDir(), "copy.wxx")
+		err := runCopy(t, "--from", "h2017.wxx", "--to", to)
+		if tc.want != "" {
+			if err == nil {
+				t.Errorf("%s: want error, got nil", tc.id)
+			} else if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("%s: want %q, got %q", tc.id, tc.want, err.Error())
+			}
+			continue
+		} else if err != nil {
+			t.Fatalf("%s: copy: want nil, got %v", tc.id, err)
+		}
+		r, _, err := info.Inspect(to)
+		if err != nil {
+			t.Fatalf("%s: inspect: want nil, got %v", tc.id, err)
+		} else if r.Encoding != "utf-8" {
+			t.Errorf("%s: encoding: want %q, got %q", tc.id, "utf-8", r.Encoding)
+		}
+	}
+}
+
+func TestCopyParseError(t *testing.T) {
+	// the map declares one tile per column but has two
+	from := fixture.Rewrite(t, `tilesHigh="2"`, `tilesHigh="1"`)
+	to := filepath.Join(t.TempDir(), "copy.wxx")
+	if err := runCopy(t, "--from", from, "--to", to, "--encoding", encUTF16BEGzip); err == nil {
+		t.Errorf("copy: want error, got nil")
+	}
+	if _, err := os.Stat(to); err == nil {
+		t.Errorf("copy: --to: want no file, got file")
+	}
+}
+
+// setConfig replaces the command configuration for the rest of the test.
+func setConfig(t *testing.T, cfg *config.Config_t) {
+	t.Helper()
+	if ottoConfig == nil {
+		if err := RegisterArgs(&config.Config_t{}); err != nil {
+			t.Fatalf("RegisterArgs: %v", err)
+		}
+	}
+	saved := *ottoConfig
+	*ottoConfig = *cfg
+	t.Cleanup(func() { *ottoConfig = saved })
+}
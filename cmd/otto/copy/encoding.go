@@ -0,0 +1,56 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/maloquacious/wxx/gzutf16"
+	"golang.org/x/text/encoding/unicode"
+	"os"
+)
+
+// output encodings. Worldographer only reads utf16be-gzip; the plain
+// encodings are for inspecting the XML with other tools.
+const (
+	encUTF16BEGzip  = "utf16be-gzip"
+	encUTF16BEPlain = "utf16be-plain"
+	encUTF8Plain    = "utf8-plain"
+)
+
+// writeMap writes the UTF-8 XML data to the named file using the given encoding.
+// The encoding in the xml header is updated to match the output.
+func writeMap(name string, data []byte, encoding string) error {
+	switch encoding {
+	case encUTF16BEGzip:
+		return gzutf16.WriteFile(name, setXMLEncoding(data, "utf-16"), 0644)
+	case encUTF16BEPlain:
+		utf16Encoding := unicode.UTF16(unicode.BigEndian, unicode.UseBOM)
+		output, err := utf16Encoding.NewEncoder().Bytes(setXMLEncoding(data, "utf-16"))
+		if err != nil {
+			return fmt.Errorf("failed to encode to UTF-16: %w", err)
+		}
+		return os.WriteFile(name, output, 0644)
+	case encUTF8Plain:
+		return os.WriteFile(name, setXMLEncoding(data, "utf-8"), 0644)
+	}
+	return fmt.Errorf("unknown encoding %q", encoding)
+}
+
+// setXMLEncoding returns the data with the encoding in the xml header
+// replaced. Data without a known header is returned unchanged.
+func setXMLEncoding(data []byte, encoding string) []byte {
+	for _, version := range []string{"1.0", "1.1"} {
+		for _, from := range []string{"utf-8", "utf-16"} {
+			header := []byte(fmt.Sprintf("<?xml version='%s' encoding='%s'?>\n", version, from))
+			if !bytes.HasPrefix(data, header) {
+				continue
+			} else if from == encoding {
+				return data
+			}
+			output := []byte(fmt.Sprintf("<?xml version='%s' encoding='%s'?>\n", version, encoding))
+			return append(output, data[len(header):]...)
+		}
+	}
+	return data
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package cli
+
+import (
+	"github.com/playbymail/otto/info"
+	"github.com/playbymail/otto/internal/fixture"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteMap(t *testing.T) {
+	input, err := info.ReadFile(fixture.Path("h2017.wxx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		encoding    string
+		compressed  bool
+		want        string // encoding reported by Inspect
+		xmlEncoding string
+	}{
+		{encoding: encUTF16BEGzip, compressed: true, want: "utf-16/be", xmlEncoding: "utf-16"},
+		{encoding: encUTF16BEPlain, compressed: false, want: "utf-16/be", xmlEncoding: "utf-16"},
+		{encoding: encUTF8Plain, compressed: false, want: "utf-8", xmlEncoding: "utf-8"},
+	} {
+		path := filepath.Join(t.TempDir(), tc.encoding+".wxx")
+		if err := writeMap(path, input, tc.encoding); err != nil {
+			t.Fatalf("%s: write: want nil, got %v", tc.encoding, err)
+		}
+		r, w, err := info.Inspect(path)
+		if err != nil {
+			t.Fatalf("%s: inspect: want nil, got %v", tc.encoding, err)
+		} else if w == nil {
+			t.Fatalf("%s: inspect: want map, got nil", tc.encoding)
+		}
+		if r.Compressed != tc.compressed {
+			t.Errorf("%s: compressed: want %v, got %v", tc.encoding, tc.compressed, r.Compressed)
+		}
+		if r.Encoding != tc.want {
+			t.Errorf("%s: encoding: want %q, got %q", tc.encoding, tc.want, r.Encoding)
+		}
+		if r.XMLEncoding != tc.xmlEncoding {
+			t.Errorf("%s: xml encoding: want %q, got %q", tc.encoding, tc.xmlEncoding, r.XMLEncoding)
+		}
+		if r.TilesHigh != 2 || r.TilesWide != 2 {
+			t.Errorf("%s: tiles: want 2x2, got %dx%d", tc.encoding, r.TilesWide, r.TilesHigh)
+		}
+	}
+}
+
+func TestSetXMLEncoding(t *testing.T) {
+	for _, tc := range []struct {
+		id       string
+		input    string
+		encoding string
+		want     string
+	}{
+		{id: "1.0 utf-16 to utf-8", input: "<?xml version='1.0' encoding='utf-16'?>\n<map/>", encoding: "utf-8",
+			want: "<?xml version='1.0' encoding='utf-8'?>\n<map/>"},
+		{id: "1.0 utf-8 to utf-16", input: "<?xml version='1.0' encoding='utf-8'?>\n<map/>", encoding: "utf-16",
+			want: "<?xml version='1.0' encoding='utf-16'?>\n<map/>"},
+		{id: "1.1 utf-16 to utf-8", input: "<?xml version='1.1' encoding='utf-16'?>\n<map/>", encoding: "utf-8",
+			want: "<?xml version='1.1' encoding='utf-8'?>\n<map/>"},
+		{id: "unchanged", input: "<?xml version='1.0' encoding='utf-16'?>\n<map/>", encoding: "utf-16",
+			want: "<?xml version='1.0' encoding='utf-16'?>\n<map/>"},
+		{id: "double quotes", input: "<?xml version=\"1.0\" encoding=\"utf-16\"?>\n<map/>", encoding: "utf-8",
+			want: "<?xml version=\"1.0\" encoding=\"utf-16\"?>\n<map/>"},
+		{id: "unknown version", input: "<?xml version='2.0' encoding='utf-16'?>\n<map/>", encoding: "utf-8",
+			want: "<?xml version='2.0' encoding='utf-16'?>\n<map/>"},
+		{id: "no header", input: "<map/>", encoding: "utf-8",
+			want: "<map/>"},
+		{id: "empty", input: "", encoding: "utf-8",
+			want: ""},
+	} {
+		if got := string(setXMLEncoding([]byte(tc.input), tc.encoding)); got != tc.want {
+			t.Errorf("%s: want %q, got %q", tc.id, tc.want, got)
+		}
+	}
+}
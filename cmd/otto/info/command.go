@@ -4,20 +4,11 @@
 package cli
 
 import (
-	"bytes"
-	"compress/gzip"
-	"encoding/xml"
-	"errors"
 	"fmt"
-	"github.com/maloquacious/wxx/models"
-	"github.com/maloquacious/wxx/xmlio"
 	"github.com/playbymail/otto/config"
+	"github.com/playbymail/otto/info"
 	"github.com/spf13/cobra"
-	"golang.org/x/text/encoding/unicode"
-	"golang.org/x/text/transform"
-	"io"
-	"os"
-	"strings"
+	"math"
 )
 
 var Command = &cobra.Command{
@@ -25,169 +16,106 @@ var Command = &cobra.Command{
 	Short: "Show map information",
 	Long:  `Info displays metadata from a map like  the Worldographer version, height, and width.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		human, err := cmd.Flags().GetBool("human")
+		if err != nil {
+			return fmt.Errorf("could not read --human: %w", err)
+		}
+		log := ottoConfig.Logger()
 		for _, arg := range args {
-			fmt.Printf("info: %q\n", arg)
-			if !strings.HasSuffix(arg, ".wxx") {
-				fmt.Printf("\tnot a '.wxx' file\n")
-				continue
-			}
-			sb, err := os.Stat(arg)
-			if err != nil {
-				if os.IsNotExist(err) {
-					fmt.Printf("\tdoes not exist\n")
-				} else {
-					fmt.Printf("\tunable to stat\n")
-				}
-				continue
-			} else if sb.IsDir() {
-				fmt.Printf("\tis a folder\n")
-			} else if !sb.Mode().IsRegular() {
-				fmt.Printf("\tis not a file\n")
-			}
-			fmt.Printf("\t%8d bytes on disk\n", sb.Size())
-			input, err := os.ReadFile(arg)
-			if err != nil {
-				fmt.Printf("\tfailed to read\n")
-				continue
-			}
-
-			// should be a gzip file
-			input, err = unzip(input)
-			if err != nil {
-				fmt.Printf("\tnot gzip compressed\n")
-			}
-			fmt.Printf("\t%8d bytes compressed\n", sb.Size())
-			fmt.Printf("\t%8d bytes uncompressed\n", len(input))
-
-			// should be UTF-16/BE
-			if len(input)%2 != 0 {
-				fmt.Printf("\tnot utf-16/be encoded\n")
-			}
-			// verify the BOM
-			if bytes.HasPrefix(input, []byte{0xfe, 0xff}) {
-				fmt.Printf("\t%8d bytes utf-16/be encoded\n", len(input))
-			} else if bytes.HasPrefix(input, []byte{0xff, 0xfe}) {
-				fmt.Printf("\t%8d bytes utf-16/le encoded\n", len(input))
-				continue
-			} else {
-				fmt.Printf("\tnot utf-16/be encoded\n")
-				continue
-			}
-
-			// convert to UTF-8
-			utf16Encoding := unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM)
-			input, err = io.ReadAll(transform.NewReader(bytes.NewReader(input), utf16Encoding.NewDecoder()))
-			fmt.Printf("\t%8d bytes utf-8     encoded\n", len(input))
-
-			// verify the xml header. the encoding may be wrong, but we'll accept it.
-			xmlHeaderIndex, xmlHeaders := -1, []struct {
-				heading  string
-				version  string
-				encoding string
-			}{
-				{heading: "<?xml version='1.0' encoding='utf-8'?>\n", version: "1.0", encoding: "utf-8"},
-				{heading: "<?xml version='1.0' encoding='utf-16'?>\n", version: "1.0", encoding: "utf-16"},
-				{heading: "<?xml version='1.1' encoding='utf-8'?>\n", version: "1.1", encoding: "utf-8"},
-				{heading: "<?xml version='1.1' encoding='utf-16'?>\n", version: "1.1", encoding: "utf-16"},
-			}
-			for i, header := range xmlHeaders {
-				if bytes.HasPrefix(input, []byte(header.heading)) {
-					xmlHeaderIndex = i
-					break
-				}
-			}
-			if xmlHeaderIndex == -1 {
-				fmt.Printf("\tmissing xml header\n")
-				continue
-			}
-			fmt.Printf("\t%8s xml version\n", xmlHeaders[xmlHeaderIndex].version)
-			fmt.Printf("\t%8s xml encoding\n", xmlHeaders[xmlHeaderIndex].encoding)
-			fmt.Printf("\t%8d bytes xml data\n", len(input))
-
-			// skip past the xml header so that we will be able to unmarshal
-			// the input to fetch the map metadata.
-			data := input[len(xmlHeaders[xmlHeaderIndex].heading):]
-			if !bytes.HasPrefix(data, []byte("<map ")) {
-				fmt.Printf("\tmissing <map> element\n")
-				continue
-			}
-
-			// read the map metadata
-			xmlMetaData, err := readMapMetadata(data)
-			if err != nil {
-				fmt.Printf("\t%v\n", err)
-				continue
-			}
-			if xmlMetaData.Release == "" && xmlMetaData.Version != "" && xmlMetaData.Schema == "" {
-				// H2017 file
-				fmt.Printf("\t%8s worldographer version\n", "H2017")
-				fmt.Printf("\t%8s version\n", xmlMetaData.Version)
-			} else if xmlMetaData.Release == "2025" && xmlMetaData.Version != "" && xmlMetaData.Schema != "" {
-				// W2025 file
-				fmt.Printf("\t%8s worldographer version\n", "W2025")
-				fmt.Printf("\t%8s version\n", xmlMetaData.Version)
-				fmt.Printf("\t%8s schema\n", xmlMetaData.Schema)
-			} else {
-				fmt.Printf("\tunknown metadata: %q %q %q\n", xmlMetaData.Release, xmlMetaData.Version, xmlMetaData.Schema)
-				continue
-			}
-
-			// read the XML from the input (including the header)
-			w, err := xmlio.ReadUTF8XML(bytes.NewReader(input))
-			if err != nil {
-				fmt.Printf("\t%v\n", err)
-				continue
-			}
-
-			fmt.Printf("\t%8d tiles high\n", w.Tiles.TilesHigh)
-			fmt.Printf("\t%8d tiles wide\n", w.Tiles.TilesWide)
-			fmt.Printf("\t%8d terrain tiles defined\n", len(w.TerrainMap.List))
+			showInfo(log, ottoConfig.MapPath(arg), human)
 		}
 		return nil
 	},
 }
 
+// ottoConfig is the configuration passed to RegisterArgs.
+var ottoConfig *config.Config_t
+
 func RegisterArgs(cfg *config.Config_t) error {
+	ottoConfig = cfg
+	Command.Flags().Bool("human", false, "show file sizes as KiB, MiB, and GiB")
 	return nil
 }
 
-type mapMetaData struct {
-	Version string `xml:"version,attr"` // required
-	Release string `xml:"release,attr"` // H2017 optional, W2025 required
-	Schema  string `xml:"schema,attr"`  // H2017 optional, W2025 required
-}
-
-// readMapMetadata
-func readMapMetadata(input []byte) (mapMetaData, error) {
-	// sanity check, sweet sanity checks
-	if !bytes.HasPrefix(input, []byte(`<map `)) {
-		return mapMetaData{}, fmt.Errorf("<map> element missing")
+// showInfo prints the report for a map file.
+// If human is set, file sizes are shown as KiB, MiB, and GiB,
+// and the on-disk, compressed, and uncompressed sizes are shown
+// without --verbose.
+func showInfo(log *config.Logger_t, path string, human bool) {
+	size := func(n int64) string {
+		if human {
+			return fmt.Sprintf("%14s", humanizeBytes(n))
+		}
+		return fmt.Sprintf("%8d bytes", n)
 	}
-	// speed up the remaining sanity checks by extracting the map attributes.
-	// we have to make the map element self-closing for this to work.
-	endOfMap := bytes.IndexByte(input, '>')
-	if endOfMap == -1 {
-		return mapMetaData{}, fmt.Errorf("<map> not closed")
+	sizef := log.Verbosef
+	if human {
+		sizef = log.Printf
 	}
-	// initialize metadata with a copy of the source up to (but not including) the first closing '>'
-	metadata := append(make([]byte, 0, endOfMap+1), input[:endOfMap]...)
-	metadata = append(metadata, '/', '>')
-	// read the version from the xml data
-	var results mapMetaData
-	if err := xml.Unmarshal(metadata, &results); err != nil {
-		return mapMetaData{}, errors.Join(models.ErrInvalidMapMetadata, err)
+	log.Printf("info: %q\n", path)
+	r, _, err := info.Inspect(path)
+	if r.BytesOnDisk != 0 {
+		sizef("\t%s on disk\n", size(r.BytesOnDisk))
+	}
+	if r.BytesUncompressed != 0 {
+		if r.Compressed {
+			sizef("\t%s compressed\n", size(r.BytesCompressed))
+		} else {
+			log.Verbosef("\t%8s not gzip compressed\n", "note:")
+		}
+		sizef("\t%s uncompressed\n", size(r.BytesUncompressed))
+		if r.Encoding == "utf-8" {
+			log.Verbosef("\t%8s not utf-16 encoded\n", "note:")
+		} else {
+			log.Verbosef("\t%s %-9s encoded\n", size(r.BytesUncompressed), r.Encoding)
+		}
+		log.Verbosef("\t%s utf-8     encoded\n", size(r.BytesUTF8))
+	}
+	if r.Encoding == "utf-16/le" {
+		log.Printf("\t%8s use `otto copy` to convert to utf-16/be\n", "hint:")
+	}
+	if r.XMLVersion != "" {
+		log.Verbosef("\t%8s xml version\n", r.XMLVersion)
+		log.Verbosef("\t%8s xml encoding\n", r.XMLEncoding)
+	}
+	if r.BytesUTF8 != 0 {
+		log.Verbosef("\t%s xml data\n", size(r.BytesUTF8))
+	}
+	if r.Worldographer != "" {
+		log.Printf("\t%8s worldographer version\n", r.Worldographer)
+		log.Printf("\t%8s version\n", r.Version)
+		if r.Schema != "" {
+			log.Printf("\t%8s schema\n", r.Schema)
+		}
+	}
+	if err != nil {
+		// when quiet, we only show the file name if there is an error
+		if log.IsQuiet() {
+			log.Errorf("info: %q\n", path)
+		}
+		log.Errorf("\t%v\n", err)
+		return
+	}
+	log.Printf("\t%8d tiles high\n", r.TilesHigh)
+	log.Printf("\t%8d tiles wide\n", r.TilesWide)
+	log.Printf("\t%8d terrain tiles defined\n", r.TerrainCount)
+	// warnings are not errors, so they are not shown with --quiet
+	for _, d := range r.Diagnostics {
+		log.Printf("\t%8s %s\n", "warn:", d.Message)
 	}
-	return results, nil
 }
 
-func unzip(input []byte) ([]byte, error) {
-	// create a new gzip reader to process the source
-	gzr, err := gzip.NewReader(bytes.NewReader(input))
-	if err != nil {
-		return nil, err
+// humanizeBytes formats a byte count using binary (1024) units.
+func humanizeBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	// pick the unit after rounding so that 1<<20-1 is "1.0 MiB", not "1024.0 KiB"
+	value, exp := float64(n)/unit, 0
+	for exp < 5 && math.Round(value*10)/10 >= unit {
+		value /= unit
+		exp++
 	}
-	defer func(gzr *gzip.Reader) {
-		_ = gzr.Close() // ignore errors
-	}(gzr)
-	return io.ReadAll(gzr)
+	return fmt.Sprintf("%.1f %ciB", value, "KMGTPE"[exp])
 }
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package cli
+
+import (
+	"bytes"
+	"github.com/playbymail/otto/config"
+	"github.com/playbymail/otto/internal/fixture"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestShowInfoLogLevels(t *testing.T) {
+	h2017 := fixture.Path("h2017.wxx")
+	missing := filepath.Join(t.TempDir(), "missing.wxx")
+	// a map with too few tiles in each column reports warnings
+	warnings := fixture.Rewrite(t, `tilesHigh="2"`, `tilesHigh="3"`)
+	for _, tc := range []struct {
+		id      string
+		level   config.LogLevel_e
+		path    string
+		want    []string
+		notWant []string
+	}{
+		{id: "normal", level: config.LogNormal, path: h2017,
+			want:    []string{"info: ", "H2017 worldographer version", "2 tiles high"},
+			notWant: []string{"bytes on disk", "xml encoding"}},
+		{id: "verbose", level: config.LogVerbose, path: h2017,
+			want: []string{"info: ", "bytes on disk", "bytes compressed", "utf-16/be encoded", "xml encoding", "2 tiles high"}},
+		{id: "quiet", level: config.LogQuiet, path: h2017,
+			notWant: []string{"info: ", "tiles high"}},
+		{id: "quiet error", level: config.LogQuiet, path: missing,
+			want: []string{"info: ", "missing.wxx", "does not exist"}},
+		{id: "normal warnings", level: config.LogNormal, path: warnings,
+			want: []string{"3 tiles high", "warn: tiles: column 0: expected 3 tiles, got 2"}},
+		{id: "quiet warnings", level: config.LogQuiet, path: warnings,
+			notWant: []string{"info: ", "warn:"}},
+	} {
+		buf := &bytes.Buffer{}
+		showInfo(&config.Logger_t{Level: tc.level, W: buf}, tc.path, false)
+		got := buf.String()
+		for _, want := range tc.want {
+			if !strings.Contains(got, want) {
+				t.Errorf("%s: want %q in output, got\n%s", tc.id, want, got)
+			}
+		}
+		for _, notWant := range tc.notWant {
+			if strings.Contains(got, notWant) {
+				t.Errorf("%s: did not want %q in output, got\n%s", tc.id, notWant, got)
+			}
+		}
+	}
+	// quiet output for a good file should be empty
+	buf := &bytes.Buffer{}
+	showInfo(&config.Logger_t{Level: config.LogQuiet, W: buf}, h2017, false)
+	if buf.Len() != 0 {
+		t.Errorf("quiet: want no output, got\n%s", buf.String())
+	}
+}
+
+func TestShowInfoHuman(t *testing.T) {
+	h2017 := fixture.Path("h2017.wxx")
+	buf := &bytes.Buffer{}
+	showInfo(&config.Logger_t{Level: config.LogNormal, W: buf}, h2017, true)
+	got := buf.String()
+	for _, want := range []string{"KiB on disk", "KiB compressed", "KiB uncompressed"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("human: want %q in output, got\n%s", want, got)
+		}
+	}
+	for _, notWant := range []string{"bytes on disk", "bytes compressed", "bytes uncompressed"} {
+		if strings.Contains(got, notWant) {
+			t.Errorf("human: did not want %q in output, got\n%s", notWant, got)
+		}
+	}
+}
+
+func TestHumanizeBytes(t *testing.T) {
+	for _, tc := range []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{1<<20 - 1, "1.0 MiB"},
+		{1 << 20, "1.0 MiB"},
+		{1 << 30, "1.0 GiB"},
+	} {
+		if got := humanizeBytes(tc.n); got != tc.want {
+			t.Errorf("%d: want %q, got %q", tc.n, tc.want, got)
+		}
+	}
+}
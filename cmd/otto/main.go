@@ -30,13 +30,48 @@ func main() {
 		Use:   "otto",
 		Short: "otto command line utility",
 		Long:  `Otto is a tool for creating TribeNet maps.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// load the configuration file. the default file is optional,
+			// but it is an error if the user names a file that doesn't exist.
+			path, err := cmd.Flags().GetString("config")
+			if err != nil {
+				return fmt.Errorf("could not read --config: %w", err)
+			} else if path != "" {
+				if err := cfg.Load(path, false); err != nil {
+					return err
+				}
+			} else if path, err = config.DefaultPath(); err == nil {
+				if err := cfg.Load(path, true); err != nil {
+					return err
+				}
+			}
+			// set the log level
+			if quiet, err := cmd.Flags().GetBool("quiet"); err != nil {
+				return fmt.Errorf("could not read --quiet: %w", err)
+			} else if quiet {
+				cfg.Log.Level = config.LogQuiet
+			}
+			if verbose, err := cmd.Flags().GetBool("verbose"); err != nil {
+				return fmt.Errorf("could not read --verbose: %w", err)
+			} else if verbose {
+				cfg.Log.Level = config.LogVerbose
+			}
+			return nil
+		},
 	}
+	cmdRoot.PersistentFlags().String("config", "", "path to configuration file (default is ~/.otto.yaml)")
+	cmdRoot.PersistentFlags().Bool("quiet", false, "only show errors")
+	cmdRoot.PersistentFlags().BoolP("verbose", "v", false, "show details for each step")
+	cmdRoot.MarkFlagsMutuallyExclusive("quiet", "verbose")
 
 	cmdRoot.AddCommand(cmdCopy.Command)
 	if err := cmdCopy.RegisterArgs(cfg); err != nil {
 		log.Fatal(err)
 	}
 	cmdRoot.AddCommand(cmdInfo.Command)
+	if err := cmdInfo.RegisterArgs(cfg); err != nil {
+		log.Fatal(err)
+	}
 	cmdRoot.AddCommand(cmdVersion.Command)
 
 	err := cmdRoot.Execute()
@@ -1,6 +1,72 @@
 // Copyright (c) 2025 Michael D Henderson. All rights reserved.
 
+// Package config implements the configuration for the otto command.
 package config
 
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+)
+
 type Config_t struct {
+	// MapsDir is the folder used to resolve relative map file names.
+	MapsDir string `yaml:"maps_dir"`
+
+	// OutputEncoding is the default encoding for files written by copy.
+	OutputEncoding string `yaml:"output_encoding"`
+
+	// Log is set from the command line, not the configuration file.
+	Log Logger_t `yaml:"-"`
+}
+
+// DefaultPath returns the path to the default configuration file,
+// which is `.otto.yaml` in the user's home directory.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".otto.yaml"), nil
+}
+
+// Load reads the configuration file and updates the values in cfg.
+// Values not set in the file are left unchanged.
+// If optional is true, a missing file is not an error.
+func (cfg *Config_t) Load(path string, optional bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if optional && errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("config: %w", err)
+	}
+	// reject unknown keys so that typos in the file are reported
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config: %s: %w", path, err)
+	}
+	return nil
+}
+
+// Logger returns the logger for command output.
+func (cfg *Config_t) Logger() *Logger_t {
+	if cfg == nil {
+		return nil
+	}
+	return &cfg.Log
+}
+
+// MapPath returns the path to a map file.
+// Relative names are resolved against MapsDir when it is set.
+func (cfg *Config_t) MapPath(name string) string {
+	if cfg == nil || cfg.MapsDir == "" || name == "" || filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(cfg.MapsDir, name)
 }
@@ -0,0 +1,86 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "otto.yaml")
+	if err := os.WriteFile(path, []byte("maps_dir: /maps\noutput_encoding: utf8-plain\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &Config_t{}
+	if err := cfg.Load(path, false); err != nil {
+		t.Fatalf("load: want nil, got %v", err)
+	}
+	if cfg.MapsDir != "/maps" {
+		t.Errorf("maps_dir: want %q, got %q", "/maps", cfg.MapsDir)
+	}
+	if cfg.OutputEncoding != "utf8-plain" {
+		t.Errorf("output_encoding: want %q, got %q", "utf8-plain", cfg.OutputEncoding)
+	}
+	for _, tc := range []struct {
+		name string
+		want string
+	}{
+		{name: "map.wxx", want: filepath.Join("/maps", "map.wxx")},
+		{name: filepath.Join("campaign", "map.wxx"), want: filepath.Join("/maps", "campaign", "map.wxx")},
+		{name: "/other/map.wxx", want: "/other/map.wxx"},
+		{name: "", want: ""},
+	} {
+		if got := cfg.MapPath(tc.name); got != tc.want {
+			t.Errorf("MapPath(%q): want %q, got %q", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.yaml")
+	cfg := &Config_t{MapsDir: "keep"}
+	if err := cfg.Load(path, true); err != nil {
+		t.Errorf("optional: want nil, got %v", err)
+	}
+	if cfg.MapsDir != "keep" {
+		t.Errorf("optional: want %q, got %q", "keep", cfg.MapsDir)
+	}
+	if err := cfg.Load(path, false); err == nil {
+		t.Errorf("required: want error, got nil")
+	}
+}
+
+func TestLoadEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.yaml")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &Config_t{}
+	if err := cfg.Load(path, false); err != nil {
+		t.Errorf("empty: want nil, got %v", err)
+	}
+}
+
+func TestLoadUnknownKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "otto.yaml")
+	if err := os.WriteFile(path, []byte("bogus_key: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &Config_t{}
+	if err := cfg.Load(path, false); err == nil {
+		t.Errorf("bogus_key: want error, got nil")
+	}
+}
+
+func TestMapPathNoMapsDir(t *testing.T) {
+	var nilConfig *Config_t
+	if got := nilConfig.MapPath("map.wxx"); got != "map.wxx" {
+		t.Errorf("nil config: want %q, got %q", "map.wxx", got)
+	}
+	if got := (&Config_t{}).MapPath("map.wxx"); got != "map.wxx" {
+		t.Errorf("empty config: want %q, got %q", "map.wxx", got)
+	}
+}
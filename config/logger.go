@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// LogLevel_e controls how much output the commands produce.
+type LogLevel_e int
+
+const (
+	LogNormal LogLevel_e = iota
+	LogQuiet
+	LogVerbose
+)
+
+// Logger_t is a leveled logger for command output.
+// The zero value writes normal output to stdout.
+type Logger_t struct {
+	Level LogLevel_e
+	W     io.Writer // defaults to os.Stdout
+}
+
+// IsQuiet returns true if the level is quiet.
+func (l *Logger_t) IsQuiet() bool {
+	return l != nil && l.Level == LogQuiet
+}
+
+// Errorf always writes to the log.
+func (l *Logger_t) Errorf(format string, args ...any) {
+	l.printf(format, args...)
+}
+
+// Printf writes to the log unless the level is quiet.
+func (l *Logger_t) Printf(format string, args ...any) {
+	if l.IsQuiet() {
+		return
+	}
+	l.printf(format, args...)
+}
+
+// Verbosef writes to the log only if the level is verbose.
+func (l *Logger_t) Verbosef(format string, args ...any) {
+	if l == nil || l.Level != LogVerbose {
+		return
+	}
+	l.printf(format, args...)
+}
+
+func (l *Logger_t) printf(format string, args ...any) {
+	var w io.Writer = os.Stdout
+	if l != nil && l.W != nil {
+		w = l.W
+	}
+	_, _ = fmt.Fprintf(w, format, args...)
+}
@@ -7,10 +7,9 @@ require (
 	github.com/maloquacious/semver v0.0.0-20250623020936-48a383c8aa95
 	github.com/maloquacious/wxx v0.0.0-20250730044946-29c894f08cf5
 	github.com/spf13/cobra v1.9.1
+	github.com/spf13/pflag v1.0.6
 	golang.org/x/text v0.27.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
-require (
-	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/spf13/pflag v1.0.6 // indirect
-)
+require github.com/inconshreveable/mousetrap v1.1.0 // indirect
@@ -0,0 +1,155 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package info
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+	"io"
+	"os"
+)
+
+// ReadFile returns the contents of a map file as UTF-8.
+// The file may be gzip compressed or plain, and may be encoded as
+// UTF-16/BE, UTF-16/LE, or UTF-8.
+func ReadFile(path string) ([]byte, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func(fp *os.File) {
+		_ = fp.Close() // ignore errors
+	}(fp)
+	d, err := newDecoder(fp, &Report{})
+	if err != nil {
+		return nil, err
+	}
+	defer func(d *decoder) {
+		_ = d.Close() // ignore errors
+	}(d)
+	data, err := io.ReadAll(d)
+	if err != nil {
+		return nil, err
+	} else if d.isUTF16 && d.uncompressed.n%2 != 0 {
+		return nil, ErrNotUTF16Encoded
+	}
+	return data, nil
+}
+
+// decoder streams a map file, decompressing it and decoding it to UTF-8.
+// It counts the bytes at each step so that we can report them without
+// holding a copy of the data.
+type decoder struct {
+	compressed   *countingReader // bytes read from the file
+	uncompressed *countingReader // bytes read after gunzip
+	decoded      *countingReader // bytes read after decoding to UTF-8
+	gzr          *gzip.Reader
+	isUTF16      bool
+}
+
+// newDecoder sniffs the input for the gzip magic bytes and a BOM.
+// It sets Compressed and Encoding in the report.
+func newDecoder(src io.Reader, r *Report) (*decoder, error) {
+	d := &decoder{compressed: &countingReader{r: src, fail: ErrFailedToRead}}
+
+	// should be a gzip file, but older or hand-edited files may be plain xml.
+	br := bufio.NewReader(d.compressed)
+	d.uncompressed = &countingReader{r: br}
+	magic, err := peek(br, 2)
+	if err != nil {
+		return nil, err
+	}
+	r.Compressed = isGzip(magic)
+	if r.Compressed {
+		gzr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidGZip, err)
+		}
+		d.gzr, d.uncompressed.r, d.uncompressed.fail = gzr, gzr, ErrInvalidGZip
+	}
+
+	// should be UTF-16/BE, but we accept UTF-16/LE since Worldographer
+	// may emit it on some platforms. verify the BOM.
+	ur := bufio.NewReader(d.uncompressed)
+	d.decoded = &countingReader{r: ur}
+	bom, err := peek(ur, 6)
+	if err != nil {
+		_ = d.Close()
+		return nil, err
+	}
+	var utf16Encoding encoding.Encoding
+	if bytes.HasPrefix(bom, []byte{0xfe, 0xff}) {
+		utf16Encoding, r.Encoding = unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM), "utf-16/be"
+	} else if bytes.HasPrefix(bom, []byte{0xff, 0xfe}) {
+		utf16Encoding, r.Encoding = unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM), "utf-16/le"
+	} else if bytes.HasPrefix(bom, []byte("<?xml ")) {
+		// hand-edited files may already be utf-8
+		r.Encoding = "utf-8"
+	} else {
+		_ = d.Close()
+		return nil, ErrUnknownEncoding
+	}
+	if utf16Encoding != nil {
+		// convert to UTF-8
+		d.decoded.r, d.decoded.fail, d.isUTF16 = transform.NewReader(ur, utf16Encoding.NewDecoder()), ErrInvalidUTF16, true
+	}
+
+	return d, nil
+}
+
+// Read implements the io.Reader interface, returning UTF-8 data.
+func (d *decoder) Read(p []byte) (int, error) {
+	return d.decoded.Read(p)
+}
+
+// Close closes the gzip reader. It does not close the source.
+func (d *decoder) Close() error {
+	if d.gzr == nil {
+		return nil
+	}
+	err := d.gzr.Close()
+	d.gzr = nil
+	return err
+}
+
+// countingReader counts the bytes read from the underlying reader.
+// If fail is set, read errors are wrapped with it so that the caller can
+// tell which step failed. Errors from an earlier step are not wrapped again.
+type countingReader struct {
+	r    io.Reader
+	n    int64
+	fail Error
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	if err != nil && err != io.EOF && cr.fail != "" {
+		if e := Error(""); !errors.As(err, &e) {
+			err = fmt.Errorf("%w: %w", cr.fail, err)
+		}
+	}
+	return n, err
+}
+
+// isGzip returns true if the input starts with the gzip magic bytes.
+func isGzip(input []byte) bool {
+	return bytes.HasPrefix(input, []byte{0x1f, 0x8b})
+}
+
+// peek returns up to n bytes from the reader without consuming them.
+// It returns fewer bytes if the input is shorter than n. Short reads
+// are handled by the caller, so only read errors are returned.
+func peek(br *bufio.Reader, n int) ([]byte, error) {
+	buf, err := br.Peek(n)
+	if err == io.EOF || errors.Is(err, bufio.ErrBufferFull) {
+		return buf, nil
+	}
+	return buf, err
+}
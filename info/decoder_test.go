@@ -0,0 +1,113 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package info
+
+import (
+	"bytes"
+	"errors"
+	"github.com/playbymail/otto/internal/fixture"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewDecoder(t *testing.T) {
+	for _, tc := range []struct {
+		id         string
+		fixture    string
+		compressed bool
+		encoding   string
+	}{
+		{id: "gzip", fixture: "h2017.wxx", compressed: true, encoding: "utf-16/be"},
+		{id: "plain", fixture: "h2017-plain.wxx", compressed: false, encoding: "utf-8"},
+	} {
+		input, err := os.ReadFile(filepath.Join("..", "testdata", tc.fixture))
+		if err != nil {
+			t.Fatalf("%s: %v", tc.id, err)
+		}
+		var r Report
+		d, err := newDecoder(bytes.NewReader(input), &r)
+		if err != nil {
+			t.Fatalf("%s: newDecoder: want nil, got %v", tc.id, err)
+		}
+		data, err := io.ReadAll(d)
+		_ = d.Close()
+		if err != nil {
+			t.Fatalf("%s: read: want nil, got %v", tc.id, err)
+		}
+		if r.Compressed != tc.compressed {
+			t.Errorf("%s: compressed: want %v, got %v", tc.id, tc.compressed, r.Compressed)
+		}
+		if r.Encoding != tc.encoding {
+			t.Errorf("%s: encoding: want %q, got %q", tc.id, tc.encoding, r.Encoding)
+		}
+		if !bytes.HasPrefix(data, []byte("<?xml version='1.0' encoding='utf-16'?>\n<map ")) {
+			t.Errorf("%s: want xml header and <map>, got %q", tc.id, data[:min(len(data), 60)])
+		}
+	}
+}
+
+func TestNewDecoderUnknownEncoding(t *testing.T) {
+	for _, tc := range []struct {
+		id    string
+		input []byte
+	}{
+		{id: "empty", input: []byte{}},
+		{id: "not xml", input: []byte("hello, world")},
+		{id: "utf-8 bom", input: []byte("\xef\xbb\xbf<?xml version='1.0' encoding='utf-8'?>\n")},
+	} {
+		var r Report
+		_, err := newDecoder(bytes.NewReader(tc.input), &r)
+		if !errors.Is(err, ErrUnknownEncoding) {
+			t.Errorf("%s: want %v, got %v", tc.id, ErrUnknownEncoding, err)
+		}
+		if r.Compressed {
+			t.Errorf("%s: compressed: want false, got true", tc.id)
+		}
+	}
+}
+
+func TestInspectUncompressed(t *testing.T) {
+	r, _, err := Inspect(filepath.Join("..", "testdata", "h2017-plain.wxx"))
+	if err != nil {
+		t.Fatalf("inspect: want nil, got %v", err)
+	}
+	if r.Compressed {
+		t.Errorf("compressed: want false, got true")
+	}
+	if r.BytesCompressed != 0 {
+		t.Errorf("bytes compressed: want 0, got %d", r.BytesCompressed)
+	}
+	if r.BytesUncompressed != r.BytesOnDisk {
+		t.Errorf("bytes uncompressed: want %d, got %d", r.BytesOnDisk, r.BytesUncompressed)
+	}
+	if r.Worldographer != "H2017" {
+		t.Errorf("worldographer: want %q, got %q", "H2017", r.Worldographer)
+	}
+}
+
+func TestTruncatedGzip(t *testing.T) {
+	input, err := os.ReadFile(fixture.Path("h2017.wxx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		id     string
+		length int
+	}{
+		{id: "header only", length: 10},
+		{id: "partial body", length: len(input) / 2},
+	} {
+		path := filepath.Join(t.TempDir(), "truncated.wxx")
+		if err := os.WriteFile(path, input[:tc.length], 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := Inspect(path); !errors.Is(err, ErrInvalidGZip) {
+			t.Errorf("%s: inspect: want %v, got %v", tc.id, ErrInvalidGZip, err)
+		}
+		if _, err := ReadFile(path); !errors.Is(err, ErrInvalidGZip) {
+			t.Errorf("%s: read: want %v, got %v", tc.id, ErrInvalidGZip, err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package info
+
+import (
+	"fmt"
+	"github.com/maloquacious/wxx/models"
+	"sort"
+)
+
+// Diagnostic is a structural problem found in a map that was read successfully.
+type Diagnostic struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Diagnostic codes.
+const (
+	DiagTileColumns      = "tile-columns"      // number of columns doesn't match tilesWide
+	DiagTileColumnCount  = "tile-column-count" // number of tiles in a column doesn't match tilesHigh
+	DiagUndefinedTerrain = "undefined-terrain" // a tile uses a terrain that isn't in the terrain map
+	DiagDuplicateTerrain = "duplicate-terrain" // the terrain map defines an index more than once
+)
+
+// checkMap returns the structural problems in the map.
+// Problems are reported once per kind and location rather than once per tile
+// so that a badly broken map doesn't flood the report.
+func checkMap(w *models.Map) (diagnostics []Diagnostic) {
+	// the terrain map should define each index once
+	terrains := map[int]string{}
+	for _, t := range w.TerrainMap.List {
+		if label, ok := terrains[t.Index]; ok {
+			diagnostics = append(diagnostics, Diagnostic{
+				Code:    DiagDuplicateTerrain,
+				Message: fmt.Sprintf("terrain %d: defined as both %q and %q", t.Index, label, t.Label),
+			})
+			continue
+		}
+		terrains[t.Index] = t.Label
+	}
+
+	// the reader stores the tiles in column-major order, so each entry in
+	// TileRows is a column. there should be tilesWide columns of tilesHigh tiles.
+	if len(w.Tiles.TileRows) != w.Tiles.TilesWide {
+		diagnostics = append(diagnostics, Diagnostic{
+			Code:    DiagTileColumns,
+			Message: fmt.Sprintf("tiles: expected %d columns, got %d", w.Tiles.TilesWide, len(w.Tiles.TileRows)),
+		})
+	}
+	undefined := map[int]int{} // count of tiles using each undefined terrain
+	for x, row := range w.Tiles.TileRows {
+		count := 0
+		for _, tile := range row {
+			if tile == nil {
+				continue
+			}
+			count++
+			if _, ok := terrains[tile.Terrain]; !ok {
+				undefined[tile.Terrain]++
+			}
+		}
+		if count != w.Tiles.TilesHigh {
+			diagnostics = append(diagnostics, Diagnostic{
+				Code:    DiagTileColumnCount,
+				Message: fmt.Sprintf("tiles: column %d: expected %d tiles, got %d", x, w.Tiles.TilesHigh, count),
+			})
+		}
+	}
+	var indexes []int
+	for index := range undefined {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		diagnostics = append(diagnostics, Diagnostic{
+			Code:    DiagUndefinedTerrain,
+			Message: fmt.Sprintf("terrain %d: not defined, used by %d tiles", index, undefined[index]),
+		})
+	}
+
+	return diagnostics
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package info
+
+import (
+	"github.com/maloquacious/wxx/models"
+	"reflect"
+	"testing"
+)
+
+func TestCheckMap(t *testing.T) {
+	for _, tc := range []struct {
+		id     string
+		update func(w *models.Map)
+		want   []Diagnostic
+	}{
+		{id: "valid",
+			update: func(w *models.Map) {}},
+		{id: "missing column",
+			update: func(w *models.Map) { w.Tiles.TileRows = w.Tiles.TileRows[:2] },
+			want: []Diagnostic{
+				{Code: DiagTileColumns, Message: "tiles: expected 3 columns, got 2"},
+			}},
+		{id: "short column",
+			update: func(w *models.Map) { w.Tiles.TileRows[1][0] = nil },
+			want: []Diagnostic{
+				{Code: DiagTileColumnCount, Message: "tiles: column 1: expected 2 tiles, got 1"},
+			}},
+		{id: "undefined terrain",
+			update: func(w *models.Map) {
+				w.Tiles.TileRows[0][1].Terrain = 9
+				w.Tiles.TileRows[2][0].Terrain = 9
+				w.Tiles.TileRows[1][1].Terrain = 7
+			},
+			want: []Diagnostic{
+				{Code: DiagUndefinedTerrain, Message: "terrain 7: not defined, used by 1 tiles"},
+				{Code: DiagUndefinedTerrain, Message: "terrain 9: not defined, used by 2 tiles"},
+			}},
+		{id: "duplicate terrain",
+			update: func(w *models.Map) {
+				w.TerrainMap.List = append(w.TerrainMap.List, &models.Terrain{Index: 1, Label: "Lake"})
+			},
+			want: []Diagnostic{
+				{Code: DiagDuplicateTerrain, Message: `terrain 1: defined as both "Ocean" and "Lake"`},
+			}},
+	} {
+		w := newTestMap(3, 2)
+		tc.update(w)
+		got := checkMap(w)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: want %+v, got %+v", tc.id, tc.want, got)
+		}
+	}
+}
+
+// newTestMap returns a valid map with the given number of tiles.
+// Every tile uses terrain 1.
+func newTestMap(wide, high int) *models.Map {
+	w := &models.Map{}
+	w.TerrainMap.List = []*models.Terrain{
+		{Index: 0, Label: "Blank"},
+		{Index: 1, Label: "Ocean"},
+		{Index: 2, Label: "Plains"},
+	}
+	w.Tiles.TilesWide, w.Tiles.TilesHigh = wide, high
+	for column := 0; column < wide; column++ {
+		var row []*models.Tile
+		for n := 0; n < high; n++ {
+			row = append(row, &models.Tile{Row: n, Column: column, Terrain: 1})
+		}
+		w.Tiles.TileRows = append(w.Tiles.TileRows, row)
+	}
+	return w
+}
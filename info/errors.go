@@ -0,0 +1,28 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package info
+
+// Error implements constant errors
+type Error string
+
+// Error implements the Errors interface
+func (e Error) Error() string {
+	return string(e)
+}
+
+const (
+	ErrFailedToRead       = Error("failed to read")
+	ErrInvalidGZip        = Error("invalid gzip")
+	ErrInvalidTiles       = Error("invalid tiles")
+	ErrInvalidUTF16       = Error("invalid utf-16")
+	ErrIsFolder           = Error("is a folder")
+	ErrMissingMapElement  = Error("missing <map> element")
+	ErrMissingXMLHeader   = Error("missing xml header")
+	ErrNotExists          = Error("does not exist")
+	ErrNotFile            = Error("is not a file")
+	ErrNotUTF16Encoded    = Error("not utf-16 encoded")
+	ErrNotWxxFile         = Error("not a '.wxx' file")
+	ErrUnableToStat       = Error("unable to stat")
+	ErrUnknownEncoding    = Error("unknown encoding: no BOM or xml header")
+	ErrUnknownMapMetadata = Error("unknown metadata")
+)
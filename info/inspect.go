@@ -0,0 +1,209 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package info implements inspecting Worldographer map files.
+package info
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/maloquacious/wxx/models"
+	"github.com/maloquacious/wxx/xmlio"
+	"io"
+	"os"
+	"strings"
+)
+
+// Report is the information that Inspect gathers from a map file.
+// Fields are filled in as the checks pass, so a Report returned
+// with an error holds everything learned before the failure.
+type Report struct {
+	// Path is the name of the file that was inspected.
+	Path string `json:"path"`
+	// BytesOnDisk is the size of the file.
+	BytesOnDisk int64 `json:"bytesOnDisk"`
+	// Compressed is true if the file is gzip compressed.
+	// Worldographer always compresses, but older or hand-edited files may not be.
+	Compressed bool `json:"compressed"`
+	// BytesCompressed is the size of the compressed data. It is set only when Compressed is true.
+	BytesCompressed int64 `json:"bytesCompressed,omitempty"`
+	// BytesUncompressed is the size of the data after gunzip, before decoding to UTF-8.
+	BytesUncompressed int64 `json:"bytesUncompressed"`
+	// Encoding is the encoding found from the BOM: "utf-16/be", "utf-16/le", or "utf-8".
+	Encoding string `json:"encoding"`
+	// BytesUTF8 is the size of the XML data after decoding to UTF-8.
+	BytesUTF8 int64 `json:"bytesUtf8"`
+	// XMLVersion is the version from the xml header, either "1.0" or "1.1".
+	XMLVersion string `json:"xmlVersion"`
+	// XMLEncoding is the encoding from the xml header. It may not match Encoding.
+	XMLEncoding string `json:"xmlEncoding"`
+	// Worldographer is the application that created the file, either "H2017" or "W2025".
+	Worldographer string `json:"worldographer"`
+	// Version, Release, and Schema are the attributes of the <map> element.
+	// H2017 files set only the version.
+	Version string `json:"version"`
+	Release string `json:"release,omitempty"`
+	Schema  string `json:"schema,omitempty"`
+	// TilesHigh and TilesWide are the dimensions of the map, in tiles.
+	TilesHigh int `json:"tilesHigh"`
+	TilesWide int `json:"tilesWide"`
+	// TerrainCount is the number of terrain types defined in the terrain map.
+	TerrainCount int `json:"terrainCount"`
+	// Diagnostics are the structural problems found in the map.
+	// A map with diagnostics was read, but Worldographer may not accept it.
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+}
+
+// Inspect reads the map file and returns a report and the parsed map.
+//
+// The file is streamed through the gzip and UTF-16 decoders instead of
+// being read into memory first. The XML parser still reads the whole
+// decoded document before parsing it.
+func Inspect(path string) (*Report, *models.Map, error) {
+	r := &Report{Path: path}
+	if !strings.HasSuffix(path, ".wxx") {
+		return r, nil, ErrNotWxxFile
+	}
+	sb, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return r, nil, ErrNotExists
+		}
+		return r, nil, fmt.Errorf("%w: %w", ErrUnableToStat, err)
+	} else if sb.IsDir() {
+		return r, nil, ErrIsFolder
+	} else if !sb.Mode().IsRegular() {
+		return r, nil, ErrNotFile
+	}
+	r.BytesOnDisk = sb.Size()
+	fp, err := os.Open(path)
+	if err != nil {
+		return r, nil, fmt.Errorf("%w: %w", ErrFailedToRead, err)
+	}
+	defer func(fp *os.File) {
+		_ = fp.Close() // ignore errors
+	}(fp)
+
+	d, err := newDecoder(fp, r)
+	if err != nil {
+		return r, nil, err
+	}
+	defer func(d *decoder) {
+		_ = d.Close() // ignore errors
+	}(d)
+
+	// buffer enough of the decoded data to hold the xml header and the <map> element
+	xr := bufio.NewReaderSize(d, 64*1024)
+	input, err := peek(xr, xr.Size())
+	if err != nil {
+		return r, nil, err
+	}
+
+	// verify the xml header. the encoding may be wrong, but we'll accept it.
+	xmlHeaderIndex, xmlHeaders := -1, []struct {
+		heading  string
+		version  string
+		encoding string
+	}{
+		{heading: "<?xml version='1.0' encoding='utf-8'?>\n", version: "1.0", encoding: "utf-8"},
+		{heading: "<?xml version='1.0' encoding='utf-16'?>\n", version: "1.0", encoding: "utf-16"},
+		{heading: "<?xml version='1.1' encoding='utf-8'?>\n", version: "1.1", encoding: "utf-8"},
+		{heading: "<?xml version='1.1' encoding='utf-16'?>\n", version: "1.1", encoding: "utf-16"},
+	}
+	for i, header := range xmlHeaders {
+		if bytes.HasPrefix(input, []byte(header.heading)) {
+			xmlHeaderIndex = i
+			break
+		}
+	}
+	if xmlHeaderIndex == -1 {
+		return r, nil, ErrMissingXMLHeader
+	}
+	r.XMLVersion = xmlHeaders[xmlHeaderIndex].version
+	r.XMLEncoding = xmlHeaders[xmlHeaderIndex].encoding
+
+	// skip past the xml header so that we will be able to unmarshal
+	// the input to fetch the map metadata.
+	data := input[len(xmlHeaders[xmlHeaderIndex].heading):]
+	if !bytes.HasPrefix(data, []byte("<map ")) {
+		return r, nil, ErrMissingMapElement
+	}
+
+	// read the map metadata
+	xmlMetaData, err := readMapMetadata(data)
+	if err != nil {
+		return r, nil, err
+	}
+	r.Version, r.Release, r.Schema = xmlMetaData.Version, xmlMetaData.Release, xmlMetaData.Schema
+	if xmlMetaData.Release == "" && xmlMetaData.Version != "" && xmlMetaData.Schema == "" {
+		r.Worldographer = "H2017"
+	} else if xmlMetaData.Release == "2025" && xmlMetaData.Version != "" && xmlMetaData.Schema != "" {
+		r.Worldographer = "W2025"
+	} else {
+		return r, nil, fmt.Errorf("%w: %q %q %q", ErrUnknownMapMetadata, xmlMetaData.Release, xmlMetaData.Version, xmlMetaData.Schema)
+	}
+
+	// read the XML from the input (including the header).
+	// this consumes the rest of the stream, so the byte counts are final after this.
+	w, err := ReadUTF8XML(xr)
+	if r.Compressed {
+		r.BytesCompressed = d.compressed.n
+	}
+	r.BytesUncompressed, r.BytesUTF8 = d.uncompressed.n, d.decoded.n
+	if err != nil {
+		return r, nil, err
+	} else if d.isUTF16 && d.uncompressed.n%2 != 0 {
+		return r, nil, ErrNotUTF16Encoded
+	}
+
+	r.TilesHigh = w.Tiles.TilesHigh
+	r.TilesWide = w.Tiles.TilesWide
+	r.TerrainCount = len(w.TerrainMap.List)
+	r.Diagnostics = checkMap(w)
+
+	return r, w, nil
+}
+
+// ReadUTF8XML wraps xmlio.ReadUTF8XML. The reader indexes tiles by the
+// tilesHigh attribute without checking it, so a map with more tiles than
+// it declares will panic. We return that as an error instead.
+// Use this instead of calling xmlio.ReadUTF8XML directly.
+func ReadUTF8XML(r io.Reader) (w *models.Map, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			w, err = nil, fmt.Errorf("%w: %v", ErrInvalidTiles, p)
+		}
+	}()
+	return xmlio.ReadUTF8XML(r)
+}
+
+type mapMetaData struct {
+	Version string `xml:"version,attr"` // required
+	Release string `xml:"release,attr"` // H2017 optional, W2025 required
+	Schema  string `xml:"schema,attr"`  // H2017 optional, W2025 required
+}
+
+// readMapMetadata
+func readMapMetadata(input []byte) (mapMetaData, error) {
+	// sanity check, sweet sanity checks
+	if !bytes.HasPrefix(input, []byte(`<map `)) {
+		return mapMetaData{}, fmt.Errorf("<map> element missing")
+	}
+	// speed up the remaining sanity checks by extracting the map attributes.
+	// we have to make the map element self-closing for this to work.
+	endOfMap := bytes.IndexByte(input, '>')
+	if endOfMap == -1 {
+		return mapMetaData{}, fmt.Errorf("<map> not closed")
+	}
+	// initialize metadata with a copy of the source up to (but not including) the first closing '>'
+	metadata := append(make([]byte, 0, endOfMap+1), input[:endOfMap]...)
+	metadata = append(metadata, '/', '>')
+	// read the version from the xml data
+	var results mapMetaData
+	if err := xml.Unmarshal(metadata, &results); err != nil {
+		return mapMetaData{}, errors.Join(models.ErrInvalidMapMetadata, err)
+	}
+	return results, nil
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package info
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"github.com/maloquacious/wxx/gzutf16"
+	"github.com/playbymail/otto/internal/fixture"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestInspectH2017(t *testing.T) {
+	path := filepath.Join("..", "testdata", "h2017.wxx")
+	onDisk := fileSize(t, path)
+	utf8 := fileSize(t, filepath.Join("..", "testdata", "h2017-plain.wxx"))
+	r, _, err := Inspect(path)
+	if err != nil {
+		t.Fatalf("inspect: want nil, got %v", err)
+	}
+	want := Report{
+		Path:              path,
+		BytesOnDisk:       onDisk,
+		Compressed:        true,
+		BytesCompressed:   onDisk,
+		BytesUncompressed: 2*utf8 + 2, // two bytes per code unit plus the BOM
+		Encoding:          "utf-16/be",
+		BytesUTF8:         utf8,
+		XMLVersion:        "1.0",
+		XMLEncoding:       "utf-16",
+		Worldographer:     "H2017",
+		Version:           "1.73",
+		TilesHigh:         2,
+		TilesWide:         2,
+		TerrainCount:      3,
+	}
+	if len(r.Diagnostics) != 0 {
+		t.Errorf("diagnostics: want none, got %+v", r.Diagnostics)
+	}
+	r.Diagnostics = nil
+	if !reflect.DeepEqual(*r, want) {
+		t.Errorf("report:\n\twant %+v\n\t got %+v", want, *r)
+	}
+}
+
+func TestInspectW2025(t *testing.T) {
+	// xmlio does not parse W2025 maps yet, but the report should hold
+	// everything found before the parser returned the error.
+	path := filepath.Join("..", "testdata", "w2025.wxx")
+	onDisk := fileSize(t, path)
+	r, w, err := Inspect(path)
+	if err == nil || !strings.Contains(err.Error(), "not yet implemented") {
+		t.Errorf("inspect: want not yet implemented, got %v", err)
+	} else if w != nil {
+		t.Errorf("inspect: want nil map, got %+v", w)
+	}
+	want := Report{
+		Path:              path,
+		BytesOnDisk:       onDisk,
+		Compressed:        true,
+		BytesCompressed:   onDisk,
+		BytesUncompressed: 508,
+		Encoding:          "utf-16/be",
+		BytesUTF8:         253,
+		XMLVersion:        "1.1",
+		XMLEncoding:       "utf-16",
+		Worldographer:     "W2025",
+		Version:           "1.10",
+		Release:           "2025",
+		Schema:            "1.01",
+	}
+	if !reflect.DeepEqual(*r, want) {
+		t.Errorf("report:\n\twant %+v\n\t got %+v", want, *r)
+	}
+}
+
+func TestInspectMap(t *testing.T) {
+	r, w, err := Inspect(filepath.Join("..", "testdata", "h2017.wxx"))
+	if err != nil {
+		t.Fatalf("inspect: want nil, got %v", err)
+	} else if w == nil {
+		t.Fatalf("inspect: want map, got nil")
+	}
+	if r.TilesHigh != w.Tiles.TilesHigh {
+		t.Errorf("tiles high: want %d, got %d", w.Tiles.TilesHigh, r.TilesHigh)
+	}
+	if r.TilesWide != w.Tiles.TilesWide {
+		t.Errorf("tiles wide: want %d, got %d", w.Tiles.TilesWide, r.TilesWide)
+	}
+	if r.TerrainCount != len(w.TerrainMap.List) {
+		t.Errorf("terrain: want %d, got %d", len(w.TerrainMap.List), r.TerrainCount)
+	}
+	if len(w.Tiles.TileRows) != 2 {
+		t.Errorf("tile rows: want 2, got %d", len(w.Tiles.TileRows))
+	}
+	if r.TerrainCount != 3 {
+		t.Errorf("terrain: want 3, got %d", r.TerrainCount)
+	}
+}
+
+func TestInspectTooManyTiles(t *testing.T) {
+	// the reader panics when a column has more tiles than tilesHigh
+	path := fixture.Rewrite(t, `tilesHigh="2"`, `tilesHigh="1"`)
+	_, w, err := Inspect(path)
+	if !errors.Is(err, ErrInvalidTiles) {
+		t.Errorf("inspect: want %v, got %v", ErrInvalidTiles, err)
+	} else if w != nil {
+		t.Errorf("inspect: want nil map, got %+v", w)
+	}
+}
+
+func TestInspectDiagnostics(t *testing.T) {
+	path := fixture.Rewrite(t, `tilesHigh="2"`, `tilesHigh="3"`)
+	r, _, err := Inspect(path)
+	if err != nil {
+		t.Fatalf("inspect: want nil, got %v", err)
+	}
+	want := []Diagnostic{
+		{Code: DiagTileColumnCount, Message: "tiles: column 0: expected 3 tiles, got 2"},
+		{Code: DiagTileColumnCount, Message: "tiles: column 1: expected 3 tiles, got 2"},
+	}
+	if !reflect.DeepEqual(r.Diagnostics, want) {
+		t.Errorf("diagnostics: want %+v, got %+v", want, r.Diagnostics)
+	}
+}
+
+func TestInspectUTF16LE(t *testing.T) {
+	r, w, err := Inspect(filepath.Join("..", "testdata", "h2017-le.wxx"))
+	if err != nil {
+		t.Fatalf("inspect: want nil, got %v", err)
+	} else if w == nil {
+		t.Fatalf("inspect: want map, got nil")
+	}
+	if r.Encoding != "utf-16/le" {
+		t.Errorf("encoding: want %q, got %q", "utf-16/le", r.Encoding)
+	}
+	if r.Worldographer != "H2017" {
+		t.Errorf("worldographer: want %q, got %q", "H2017", r.Worldographer)
+	}
+	if r.Version != "1.73" {
+		t.Errorf("version: want %q, got %q", "1.73", r.Version)
+	}
+	if r.TilesHigh != 2 || r.TilesWide != 2 {
+		t.Errorf("tiles: want 2x2, got %dx%d", r.TilesWide, r.TilesHigh)
+	}
+}
+
+func TestInspectLarge(t *testing.T) {
+	path := writeLargeMap(t, 300, 200)
+	r, w, err := Inspect(path)
+	if err != nil {
+		t.Fatalf("inspect: want nil, got %v", err)
+	} else if w == nil {
+		t.Fatalf("inspect: want map, got nil")
+	}
+	if r.TilesWide != 300 || r.TilesHigh != 200 {
+		t.Errorf("tiles: want 300x200, got %dx%d", r.TilesWide, r.TilesHigh)
+	}
+	if !r.Compressed || r.Encoding != "utf-16/be" {
+		t.Errorf("encoding: want compressed utf-16/be, got %v %q", r.Compressed, r.Encoding)
+	}
+	if r.BytesUncompressed != 2*r.BytesUTF8+2 {
+		t.Errorf("bytes: want %d uncompressed for %d utf-8, got %d", 2*r.BytesUTF8+2, r.BytesUTF8, r.BytesUncompressed)
+	}
+	if len(r.Diagnostics) != 0 {
+		t.Errorf("diagnostics: want none, got %+v", r.Diagnostics)
+	}
+}
+
+func BenchmarkInspect(b *testing.B) {
+	path := writeLargeMap(b, 300, 200)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, _, err := Inspect(path); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkInspectBuffered reads the map the way Inspect did before it
+// streamed the file: read the whole file, gunzip it, then decode it.
+// Compare its allocations with BenchmarkInspect. The savings are modest
+// because ReadUTF8XML still reads the whole decoded document.
+func BenchmarkInspectBuffered(b *testing.B) {
+	path := writeLargeMap(b, 300, 200)
+	b.ReportAllocs()
+	for b.Loop() {
+		input, err := os.ReadFile(path)
+		if err != nil {
+			b.Fatal(err)
+		}
+		gzr, err := gzip.NewReader(bytes.NewReader(input))
+		if err != nil {
+			b.Fatal(err)
+		}
+		input, err = io.ReadAll(gzr)
+		if err != nil {
+			b.Fatal(err)
+		}
+		utf16Encoding := unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM)
+		input, err = io.ReadAll(transform.NewReader(bytes.NewReader(input), utf16Encoding.NewDecoder()))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := ReadUTF8XML(bytes.NewReader(input)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// fileSize returns the size of the file in bytes.
+func fileSize(tb testing.TB, path string) int64 {
+	tb.Helper()
+	sb, err := os.Stat(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return sb.Size()
+}
+
+// reTiles matches the tiles element in the test fixtures.
+var reTiles = regexp.MustCompile(`(?s)<tiles [^>]*>.*</tiles>\n`)
+
+// writeLargeMap writes a gzip'd UTF-16/BE map with the given number of
+// tiles to a temporary folder and returns the path to the file.
+func writeLargeMap(tb testing.TB, wide, high int) string {
+	tb.Helper()
+	input, err := os.ReadFile(filepath.Join("..", "testdata", "h2017-plain.wxx"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	sb := &strings.Builder{}
+	sb.WriteString("<tiles viewLevel=\"WORLD\" tilesWide=\"")
+	sb.WriteString(strconv.Itoa(wide))
+	sb.WriteString("\" tilesHigh=\"")
+	sb.WriteString(strconv.Itoa(high))
+	sb.WriteString("\">\n")
+	for column := 0; column < wide; column++ {
+		sb.WriteString("<tilerow>\n")
+		sb.WriteString(strings.Repeat("1\t0\t0\t0\t0\tZ\n", high))
+		sb.WriteString("</tilerow>\n")
+	}
+	sb.WriteString("</tiles>\n")
+	data := reTiles.ReplaceAll(input, []byte(sb.String()))
+	path := filepath.Join(tb.TempDir(), "large.wxx")
+	if err := gzutf16.WriteFile(path, data, 0644); err != nil {
+		tb.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package fixture implements helpers for tests that use the map files
+// in the repository's testdata folder.
+package fixture
+
+import (
+	"bytes"
+	"github.com/maloquacious/wxx/gzutf16"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// Path returns the path to the named file in the testdata folder.
+func Path(name string) string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "testdata", name)
+}
+
+// Rewrite replaces old with new in the H2017 fixture, writes it
+// as a gzip'd UTF-16/BE map to a temporary folder, and returns the path.
+func Rewrite(tb testing.TB, old, new string) string {
+	tb.Helper()
+	input, err := os.ReadFile(Path("h2017-plain.wxx"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	path := filepath.Join(tb.TempDir(), "rewrite.wxx")
+	if err := gzutf16.WriteFile(path, bytes.Replace(input, []byte(old), []byte(new), 1), 0644); err != nil {
+		tb.Fatal(err)
+	}
+	return path
+}